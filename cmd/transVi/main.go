@@ -1,26 +1,110 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"transVi/internal/ffmpeg"
+	"transVi/internal/manifest"
+	"transVi/internal/srt"
+	"transVi/internal/transcriber"
 )
 
+// Chunk describes one audio segment produced by the split step, whichever
+// split mode produced it. Index is the segment's position in the original
+// file and Start is where that segment actually begins in the source
+// video, so the SRT merger can offset cues correctly regardless of
+// whether chunks are a fixed length or variable, silence-snapped ones.
 type Chunk struct {
+	Index  int
+	Start  time.Duration
 	Input  string
 	Output string
 }
 
+// segmentListPath is where ffmpeg writes the CSV describing each segment
+// it produced: "filename,start_time,end_time" per line.
+const segmentListPath = "audio_parts/segments.csv"
+
+// overlapTolerance is how close the end of the previous chunk's last cue
+// and the next chunk's first cue can be before we treat them as the same
+// spoken cue split across the segment boundary and drop the duplicate.
+const overlapTolerance = 250 * time.Millisecond
+
+var partIndexRe = regexp.MustCompile(`part(\d+)\.wav$`)
+
+// subtitleStyle holds the styling knobs exposed for burned-in subtitles.
+// Position is one of "top", "middle", "bottom" and maps to an ASS
+// alignment value for force_style.
+type subtitleStyle struct {
+	Font     string
+	Size     int
+	Outline  int
+	Position string
+}
+
+// assAlignment maps subtitleStyle.Position to the numeric ASS \an
+// alignment codes libass understands.
+var assAlignment = map[string]int{
+	"bottom": 2,
+	"middle": 5,
+	"top":    8,
+}
+
+// movTextContainers lists output extensions that can carry an mov_text
+// subtitle track, the codec ffmpeg uses for soft subtitles in MP4/MOV.
+var movTextContainers = map[string]bool{
+	".mp4": true,
+	".mov": true,
+	".m4v": true,
+}
+
+// manifestPath returns where the resume manifest for input lives:
+// .transvi/manifest.json next to it.
+func manifestPath(input string) string {
+	return filepath.Join(filepath.Dir(input), ".transvi", "manifest.json")
+}
+
 func main() {
 	var (
-		input   = flag.String("input", "", "Input video file path")
-		output  = flag.String("output", "output.mp4", "Output video with subtitles")
-		workers = flag.Int("workers", 6, "Number of parallel workers (default: 6)")
+		input            = flag.String("input", "", "Input video file path")
+		output           = flag.String("output", "output.mp4", "Output video with subtitles")
+		workers          = flag.Int("workers", 6, "Number of parallel workers (default: 6)")
+		splitMode        = flag.String("split-mode", "fixed", "How to cut the input into chunks: \"fixed\" or \"silence\"")
+		silenceNoise     = flag.String("silence-noise", "-30dB", "silencedetect noise threshold (silence split-mode only)")
+		silenceMinDur    = flag.Float64("silence-min-duration", 0.4, "Minimum silence length in seconds to count as a cut point (silence split-mode only)")
+		maxChunkLength   = flag.Float64("max-chunk-length", 30, "Maximum chunk length in seconds (silence split-mode only)")
+		minChunkLength   = flag.Float64("min-chunk-length", 5, "Minimum chunk length in seconds (silence split-mode only)")
+		subtitleMode     = flag.String("subtitle-mode", "soft", "How to deliver subtitles: \"soft\" (mux track), \"burn\" (hardcode into video), or \"sidecar\" (write .srt only)")
+		subtitleFont     = flag.String("subtitle-font", "Arial", "Font used for burned-in subtitles (burn subtitle-mode only)")
+		subtitleSize     = flag.Int("subtitle-size", 24, "Font size used for burned-in subtitles (burn subtitle-mode only)")
+		subtitleOutline  = flag.Int("subtitle-outline", 2, "Outline width used for burned-in subtitles (burn subtitle-mode only)")
+		subtitlePos      = flag.String("subtitle-position", "bottom", "Position for burned-in subtitles: \"top\", \"middle\", or \"bottom\" (burn subtitle-mode only)")
+		resume           = flag.Bool("resume", false, "Resume from .transvi/manifest.json next to -input, skipping chunks already transcribed")
+		force            = flag.Bool("force", false, "Ignore any existing manifest for -input and start the pipeline from scratch")
+		backend          = flag.String("backend", "whispercpp", "Transcription backend: \"whispercpp\", \"whisperhttp\", or \"openai\"")
+		whisperCppBin    = flag.String("whisper-cpp-bin", "whisper.cpp/main", "Path to whisper.cpp's main binary (whispercpp backend only)")
+		whisperModelPath = flag.String("whisper-model-path", "models/ggml-base.en.bin", "Path to the whisper.cpp model file (whispercpp backend only)")
+		whisperThreads   = flag.Int("whisper-threads", 0, "Threads passed to whisper.cpp; 0 uses its default (whispercpp backend only)")
+		whisperLanguage  = flag.String("whisper-language", "en", "Source language passed to whisper.cpp, or \"auto\" (whispercpp backend only)")
+		whisperTranslate = flag.Bool("whisper-translate", false, "Ask whisper.cpp to translate the audio to English (whispercpp backend only)")
+		endpoint         = flag.String("endpoint", "", "Server URL to POST audio to (whisperhttp backend only)")
+		openaiModel      = flag.String("openai-model", "whisper-1", "Model name sent to the OpenAI transcriptions endpoint (openai backend only)")
+		rateLimit        = flag.Float64("rate-limit", 0, "Max requests/sec to the transcription backend; 0 disables limiting (whisperhttp and openai backends only)")
 	)
 	fmt.Printf("Output will be saved to: %s\n", *output) // Dummy usage to prevent unused variable error
 	flag.Parse()
@@ -29,59 +113,166 @@ func main() {
 		fmt.Println("Error: -input is required")
 		os.Exit(1)
 	}
+	if *resume && *force {
+		fmt.Println("Error: -resume and -force are mutually exclusive")
+		os.Exit(1)
+	}
 
-	// 1. Create directories
-	err := os.MkdirAll("audio_parts", 0755)
+	limiter := transcriber.NewRateLimiter(*rateLimit, 1)
+	var tc transcriber.Transcriber
+	var modelLabel string
+	switch *backend {
+	case "whispercpp":
+		tc = transcriber.WhisperCPP{
+			BinaryPath: *whisperCppBin,
+			ModelPath:  *whisperModelPath,
+			Threads:    *whisperThreads,
+			Language:   *whisperLanguage,
+			Translate:  *whisperTranslate,
+		}
+		modelLabel = "whispercpp:" + *whisperModelPath
+	case "whisperhttp":
+		if *endpoint == "" {
+			fmt.Println("Error: -endpoint is required for -backend whisperhttp")
+			os.Exit(1)
+		}
+		tc = transcriber.WhisperHTTP{Endpoint: *endpoint, RateLimiter: limiter}
+		modelLabel = "whisperhttp:" + *endpoint
+	case "openai":
+		tc = transcriber.OpenAIAPI{Model: *openaiModel, RateLimiter: limiter}
+		modelLabel = "openai:" + *openaiModel
+	default:
+		fmt.Printf("Error: unknown -backend %q (want \"whispercpp\", \"whisperhttp\", or \"openai\")\n", *backend)
+		os.Exit(1)
+	}
+
+	mPath := manifestPath(*input)
+	inputHash, err := manifest.HashFile(*input)
 	if err != nil {
-		fmt.Printf("Error creating audio_parts directory: %v\n", err)
+		fmt.Printf("Error hashing -input: %v\n", err)
 		os.Exit(1)
 	}
 
-	// 2. Split audio using ffmpeg
-	ffmpegCmd := exec.Command("ffmpeg",
-		"-i", *input,
-		"-vn", "-c:a", "pcm_s16le",
-		"-ar", "16000",
-		"-f", "segment",
-		"-segment_time", "30",
-		"-reset_timestamps", "1",
-		"audio_parts/part%03d.wav",
-	)
-	err = runCommand(ffmpegCmd)
+	existing, err := manifest.Load(mPath)
 	if err != nil {
-		fmt.Printf("FFmpeg split failed: %v\n", err)
+		fmt.Printf("Error reading %s: %v\n", mPath, err)
+		os.Exit(1)
+	}
+	if existing != nil && existing.InputHash != inputHash {
+		existing = nil // manifest is for a different (or since-edited) input
+	}
+	if existing != nil && !*resume && !*force {
+		fmt.Printf("Error: found an existing manifest at %s for this input; pass -resume to continue it or -force to start over\n", mPath)
+		os.Exit(1)
+	}
+	if *force {
+		existing = nil
+		os.RemoveAll(filepath.Dir(mPath))
+		// A crashed or Ctrl-C'd attempt can leave partial split output
+		// behind; without clearing it, the next split step either fails
+		// (ffmpeg refuses to overwrite existing part files) or silently
+		// mixes stale parts into the new plan.
+		os.RemoveAll("audio_parts")
+		os.RemoveAll("subtitles")
+	}
+
+	// 1. Create directories
+	err = os.MkdirAll("audio_parts", 0755)
+	if err != nil {
+		fmt.Printf("Error creating audio_parts directory: %v\n", err)
 		os.Exit(1)
 	}
 
-	// 3. Process chunks in parallel
+	// 2. Split audio into chunks, or reuse the plan from an existing
+	// manifest so a resumed run doesn't touch ffmpeg (and the audio_parts
+	// files it already produced) again.
 	var chunks []Chunk
-	err = filepath.WalkDir("audio_parts", func(path string, d fs.DirEntry, err error) error {
+	var mf *manifest.Manifest
+	if existing != nil {
+		mf = existing
+		for _, cs := range existing.Chunks {
+			chunks = append(chunks, Chunk{
+				Index:  cs.Index,
+				Start:  time.Duration(cs.StartSeconds * float64(time.Second)),
+				Input:  filepath.Join("audio_parts", fmt.Sprintf("part%03d.wav", cs.Index)),
+				Output: cs.SRTPath,
+			})
+		}
+		sort.Slice(chunks, func(i, j int) bool { return chunks[i].Index < chunks[j].Index })
+	} else {
+		switch *splitMode {
+		case "fixed":
+			chunks, err = splitFixed(*input)
+		case "silence":
+			chunks, err = splitSilence(*input, silenceSplitOptions{
+				Noise:          *silenceNoise,
+				MinSilenceDur:  *silenceMinDur,
+				MaxChunkLength: *maxChunkLength,
+				MinChunkLength: *minChunkLength,
+			})
+		default:
+			fmt.Printf("Error: unknown -split-mode %q (want \"fixed\" or \"silence\")\n", *splitMode)
+			os.Exit(1)
+		}
 		if err != nil {
-			return err
+			fmt.Printf("Audio split failed: %v\n", err)
+			os.Exit(1)
 		}
-		if !d.IsDir() && filepath.Ext(path) == ".wav" {
-			chunks = append(chunks, Chunk{
-				Input:  path,
-				Output: filepath.Join("subtitles", filepath.Base(path)+".srt"),
+
+		var chunkStates []manifest.ChunkState
+		for _, c := range chunks {
+			chunkStates = append(chunkStates, manifest.ChunkState{
+				Index:        c.Index,
+				StartSeconds: c.Start.Seconds(),
+				Status:       manifest.StatusPending,
+				Model:        modelLabel,
+				SRTPath:      c.Output,
 			})
 		}
-		return nil
-	})
+		mf = manifest.New(inputHash, chunkStates)
+		if err := mf.Save(mPath); err != nil {
+			fmt.Printf("Error writing manifest: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
+	// 3. Process chunks in parallel, skipping ones the manifest already
+	// marks done from a previous run.
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, *workers)
 	errChan := make(chan error, len(chunks))
 
 	for _, chunk := range chunks {
+		if cs, ok := mf.ChunkState(chunk.Index); ok && cs.Status == manifest.StatusDone {
+			continue
+		}
+
 		wg.Add(1)
 		semaphore <- struct{}{}
 		go func(c Chunk) {
 			defer wg.Done()
 			defer func() { <-semaphore }()
 
-			err := processChunk(c)
+			mf.SetStatus(c.Index, manifest.StatusRunning, 0)
+			if err := mf.Save(mPath); err != nil {
+				errChan <- fmt.Errorf("saving manifest: %w", err)
+				return
+			}
+
+			start := time.Now()
+			err := processChunk(c, tc)
 			if err != nil {
+				mf.SetStatus(c.Index, manifest.StatusFailed, 0)
+				if saveErr := mf.Save(mPath); saveErr != nil {
+					err = fmt.Errorf("%w (also failed saving manifest: %v)", err, saveErr)
+				}
 				errChan <- err
+				return
+			}
+			mf.SetStatus(c.Index, manifest.StatusDone, time.Since(start).Seconds())
+			if err := mf.Save(mPath); err != nil {
+				errChan <- fmt.Errorf("saving manifest: %w", err)
+				return
 			}
 		}(chunk)
 	}
@@ -94,43 +285,348 @@ func main() {
 		os.Exit(1)
 	}
 
-	// 4. Merge subtitles and re-encode
-	err = mergeSubtitlesAndReencode(*input, *output)
+	// 4. Merge per-chunk subtitles into one correctly timed .srt file.
+	mergedSRT, err := mergeSubtitles(chunks)
 	if err != nil {
-		fmt.Printf("Merge and reencode failed: %v\n", err)
+		fmt.Printf("Subtitle merge failed: %v\n", err)
 		os.Exit(1)
 	}
+
+	// 5. Deliver the merged subtitles per -subtitle-mode.
+	style := subtitleStyle{
+		Font:     *subtitleFont,
+		Size:     *subtitleSize,
+		Outline:  *subtitleOutline,
+		Position: *subtitlePos,
+	}
+	err = applySubtitles(*input, mergedSRT, *output, *subtitleMode, style)
+	if err != nil {
+		fmt.Printf("Applying subtitles failed: %v\n", err)
+		os.Exit(1)
+	}
+	// Only clean up once the whole pipeline, including mux/burn, has
+	// succeeded — a crash or Ctrl-C before this point leaves audio_parts,
+	// subtitles, and the manifest in place for a cheap -resume.
 	defer func() {
 		os.RemoveAll("audio_parts")
 		os.RemoveAll("subtitles")
+		os.RemoveAll(filepath.Dir(mPath))
 	}()
 }
 
-func mergeSubtitlesAndReencode(input, output string) error {
-	var sb strings.Builder
-	err := filepath.WalkDir("subtitles", func(path string, d fs.DirEntry, err error) error {
+// splitFixed cuts input into fixed-length 30s chunks with ffmpeg's segment
+// muxer, then enumerates audio_parts/ for the resulting files. It asks
+// ffmpeg to record the real start/end time of each segment via
+// "-segment_list": segments are snapped to keyframes, so they are rarely
+// exactly 30s apart and callers can't assume otherwise.
+func splitFixed(input string) ([]Chunk, error) {
+	ffmpegCmd := exec.Command("ffmpeg",
+		"-i", input,
+		"-vn", "-c:a", "pcm_s16le",
+		"-ar", "16000",
+		"-f", "segment",
+		"-segment_time", "30",
+		"-reset_timestamps", "1",
+		"-segment_list", segmentListPath,
+		"-segment_list_type", "csv",
+		"audio_parts/part%03d.wav",
+	)
+	if err := runCommand(ffmpegCmd); err != nil {
+		return nil, fmt.Errorf("splitFixed: ffmpeg segment: %w", err)
+	}
+
+	chunkStarts, err := ffmpeg.ParseSegmentList(segmentListPath)
+	if err != nil {
+		return nil, fmt.Errorf("splitFixed: %w", err)
+	}
+
+	var chunks []Chunk
+	err = filepath.WalkDir("audio_parts", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() && filepath.Ext(path) == ".srt" {
-			data, err := os.ReadFile(path)
+		if !d.IsDir() && filepath.Ext(path) == ".wav" {
+			base := filepath.Base(path)
+			m := partIndexRe.FindStringSubmatch(base)
+			if m == nil {
+				return fmt.Errorf("unexpected chunk filename %q", base)
+			}
+			index, err := strconv.Atoi(m[1])
 			if err != nil {
 				return err
 			}
-			sb.Write(data)
+			chunks = append(chunks, Chunk{
+				Index:  index,
+				Start:  chunkStarts[base],
+				Input:  path,
+				Output: filepath.Join("subtitles", base+".srt"),
+			})
 		}
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("splitFixed: enumerate chunks: %w", err)
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].Index < chunks[j].Index })
+	return chunks, nil
+}
+
+// silenceSplitOptions configures splitSilence's cut point search.
+type silenceSplitOptions struct {
+	Noise          string  // silencedetect noise threshold, e.g. "-30dB"
+	MinSilenceDur  float64 // silencedetect minimum silence duration, seconds
+	MaxChunkLength float64 // longest a chunk may be before we force a cut
+	MinChunkLength float64 // shortest a chunk may be; silences closer than this to the chunk's start or the input's end are not used as cuts
+}
+
+// silenceInterval is one silence_start/silence_end pair reported by
+// ffmpeg's silencedetect filter.
+type silenceInterval struct {
+	Start, End float64
+}
+
+var (
+	silenceStartRe = regexp.MustCompile(`silence_start:\s*([0-9.]+)`)
+	silenceEndRe   = regexp.MustCompile(`silence_end:\s*([0-9.]+)`)
+)
+
+// splitSilence cuts input into variable-length chunks by first detecting
+// silences with ffmpeg's silencedetect filter, then choosing cut points
+// that keep every chunk within [MinChunkLength, MaxChunkLength] where the
+// input's length allows, placing them inside those silences when one is
+// available (see planCutPoints). This avoids the fixed-window split
+// slicing through the middle of a spoken word at every boundary.
+func splitSilence(input string, opts silenceSplitOptions) ([]Chunk, error) {
+	duration, err := ffmpeg.ProbeDuration(input)
+	if err != nil {
+		return nil, fmt.Errorf("splitSilence: %w", err)
+	}
+
+	silences, err := detectSilences(input, opts.Noise, opts.MinSilenceDur)
+	if err != nil {
+		return nil, fmt.Errorf("splitSilence: %w", err)
+	}
+
+	cutPoints := planCutPoints(duration, silences, opts.MaxChunkLength, opts.MinChunkLength)
+
+	var chunks []Chunk
+	for i := 0; i < len(cutPoints)-1; i++ {
+		start, end := cutPoints[i], cutPoints[i+1]
+		base := fmt.Sprintf("part%03d.wav", i)
+		outPath := filepath.Join("audio_parts", base)
+
+		ffmpegCmd := exec.Command("ffmpeg",
+			"-ss", fmt.Sprintf("%f", start),
+			"-to", fmt.Sprintf("%f", end),
+			"-i", input,
+			"-vn", "-c:a", "pcm_s16le",
+			"-ar", "16000",
+			outPath,
+		)
+		if err := runCommand(ffmpegCmd); err != nil {
+			return nil, fmt.Errorf("splitSilence: ffmpeg chunk %d: %w", i, err)
+		}
+
+		chunks = append(chunks, Chunk{
+			Index:  i,
+			Start:  time.Duration(start * float64(time.Second)),
+			Input:  outPath,
+			Output: filepath.Join("subtitles", base+".srt"),
+		})
+	}
+	return chunks, nil
+}
+
+// planCutPoints walks the detected silences in order and greedily cuts
+// whenever the current chunk would otherwise exceed maxLen, snapping the
+// cut to the midpoint of a silence whose position leaves both the
+// resulting chunks at least minLen. If no such silence occurs before
+// maxLen is reached, it falls back to a hard cut at maxLen, pulled back
+// to leave minLen for the final chunk when a cut at maxLen would leave
+// less and the current chunk can spare it.
+func planCutPoints(duration float64, silences []silenceInterval, maxLen, minLen float64) []float64 {
+	cuts := []float64{0}
+	chunkStart := 0.0
+
+	for chunkStart < duration {
+		limit := chunkStart + maxLen
+		if limit >= duration {
+			break
+		}
+
+		cut := limit
+		for _, s := range silences {
+			mid := (s.Start + s.End) / 2
+			if mid <= chunkStart+minLen || mid >= limit || duration-mid < minLen {
+				continue
+			}
+			// Prefer the silence closest to the max-length limit, so
+			// chunks stay as close to maxLen as the audio allows.
+			if mid > cut || cut == limit {
+				cut = mid
+			}
+		}
+		if cut == limit && duration-cut < minLen && duration-minLen > chunkStart+minLen {
+			cut = duration - minLen
+		}
+
+		cuts = append(cuts, cut)
+		chunkStart = cut
+	}
+
+	cuts = append(cuts, duration)
+	return cuts
+}
+
+// detectSilences runs ffmpeg's silencedetect filter over input and parses
+// the silence_start/silence_end pairs it prints to stderr.
+func detectSilences(input, noise string, minDur float64) ([]silenceInterval, error) {
+	cmd := exec.Command("ffmpeg",
+		"-i", input,
+		"-af", fmt.Sprintf("silencedetect=noise=%s:d=%f", noise, minDur),
+		"-f", "null", "-",
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("detectSilences: ffmpeg silencedetect: %w", err)
+	}
+
+	var silences []silenceInterval
+	var pendingStart float64
+	haveStart := false
+
+	scanner := bufio.NewScanner(&stderr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := silenceStartRe.FindStringSubmatch(line); m != nil {
+			pendingStart, _ = strconv.ParseFloat(m[1], 64)
+			haveStart = true
+			continue
+		}
+		if m := silenceEndRe.FindStringSubmatch(line); m != nil && haveStart {
+			end, _ := strconv.ParseFloat(m[1], 64)
+			silences = append(silences, silenceInterval{Start: pendingStart, End: end})
+			haveStart = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("detectSilences: %w", err)
+	}
+	return silences, nil
+}
+
+// mergeSubtitles reads each chunk's SRT file, shifts its cues by that
+// chunk's real start offset, drops cues that duplicate content already
+// covered by the end of the previous chunk, renumbers the result
+// monotonically, writes it to merged_sub_titles.srt, and returns that
+// path.
+func mergeSubtitles(chunks []Chunk) (string, error) {
+	var merged []srt.Cue
+	var prevChunkEnd time.Duration
+	havePrevChunk := false
+
+	for _, c := range chunks {
+		f, err := os.Open(c.Output)
+		if err != nil {
+			return "", fmt.Errorf("mergeSubtitles: open %s: %w", c.Output, err)
+		}
+		cues, err := srt.Parse(f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("mergeSubtitles: parse %s: %w", c.Output, err)
+		}
+
+		shifted := srt.Shift(cues, c.Start)
+		for i, cue := range shifted {
+			// Only the first cue of this chunk can duplicate the tail of
+			// the previous chunk's transcript (the same spoken line
+			// re-transcribed on both sides of the segment boundary);
+			// cues within a single chunk's own SRT are never compared
+			// against each other here, since whisper routinely emits
+			// back-to-back cues well under overlapTolerance apart during
+			// continuous speech.
+			if i == 0 && havePrevChunk && cue.Start < prevChunkEnd+overlapTolerance {
+				continue
+			}
+			merged = append(merged, cue)
+		}
+
+		if len(shifted) > 0 {
+			prevChunkEnd = shifted[len(shifted)-1].End
+			havePrevChunk = true
+		}
+	}
+
+	for i := range merged {
+		merged[i].Index = i + 1
 	}
 
 	mergedSubtitles := "merged_sub_titles.srt" // Fixed filename to avoid FFmpeg path issues
-	err = os.WriteFile(mergedSubtitles, []byte(sb.String()), 0644)
+	f, err := os.Create(mergedSubtitles)
 	if err != nil {
-		return err
+		return "", err
+	}
+	defer f.Close()
+	if err := srt.Write(f, merged); err != nil {
+		return "", err
+	}
+	return mergedSubtitles, nil
+}
+
+// applySubtitles delivers mergedSRT according to mode:
+//
+//   - "soft" muxes it into output as an mov_text subtitle track, keeping
+//     the original video/audio streams untouched (-c copy).
+//   - "burn" hardcodes styled subtitles into the video with the
+//     "subtitles" filter, re-encoding video but copying audio.
+//   - "sidecar" leaves the video alone and just places the .srt next to
+//     it, named after output.
+func applySubtitles(input, mergedSRT, output, mode string, style subtitleStyle) error {
+	switch mode {
+	case "soft":
+		ext := strings.ToLower(filepath.Ext(output))
+		if !movTextContainers[ext] {
+			return fmt.Errorf("applySubtitles: soft mode needs an mp4/mov/m4v output container to carry an mov_text track, got %q", ext)
+		}
+		cmd := exec.Command("ffmpeg",
+			"-i", input,
+			"-i", mergedSRT,
+			"-map", "0", "-map", "1",
+			"-c", "copy",
+			"-c:s", "mov_text",
+			output,
+		)
+		return runCommand(cmd)
+
+	case "burn":
+		alignment, ok := assAlignment[style.Position]
+		if !ok {
+			return fmt.Errorf("applySubtitles: unknown -subtitle-position %q", style.Position)
+		}
+		forceStyle := fmt.Sprintf(
+			"FontName=%s,FontSize=%d,Outline=%d,Alignment=%d",
+			style.Font, style.Size, style.Outline, alignment,
+		)
+		cmd := exec.Command("ffmpeg",
+			"-i", input,
+			"-vf", fmt.Sprintf("subtitles=%s:force_style='%s'", mergedSRT, forceStyle),
+			"-c:a", "copy",
+			output,
+		)
+		return runCommand(cmd)
+
+	case "sidecar":
+		sidecarPath := strings.TrimSuffix(output, filepath.Ext(output)) + ".srt"
+		data, err := os.ReadFile(mergedSRT)
+		if err != nil {
+			return fmt.Errorf("applySubtitles: %w", err)
+		}
+		return os.WriteFile(sidecarPath, data, 0644)
+
+	default:
+		return fmt.Errorf("applySubtitles: unknown -subtitle-mode %q (want \"soft\", \"burn\", or \"sidecar\")", mode)
 	}
-	return nil
 }
 
 func runCommand(cmd *exec.Cmd) error {
@@ -140,19 +636,29 @@ func runCommand(cmd *exec.Cmd) error {
 	return cmd.Run()
 }
 
-func processChunk(c Chunk) error {
+// processChunk transcribes one chunk with tc and writes the result to
+// c.Output.
+func processChunk(c Chunk, tc transcriber.Transcriber) error {
 	// Create output directory
 	err := os.MkdirAll("subtitles", 0755)
 	if err != nil {
 		return err
 	}
 
-	// Run Whisper.cpp
-	whisperCmd := exec.Command("whisper",
-		c.Input,
-		"--model", "base.en", // Adjust model as needed
-		"-f", "srt",
-		"-o", c.Output,
-	)
-	return runCommand(whisperCmd)
+	rc, err := tc.Transcribe(context.Background(), c.Input)
+	if err != nil {
+		return fmt.Errorf("processChunk: %w", err)
+	}
+	defer rc.Close()
+
+	out, err := os.Create(c.Output)
+	if err != nil {
+		return fmt.Errorf("processChunk: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, rc); err != nil {
+		return fmt.Errorf("processChunk: %w", err)
+	}
+	return nil
 }
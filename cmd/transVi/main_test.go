@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"transVi/internal/srt"
+)
+
+// writeSRT writes cues to path as a minimal .srt file, creating parent
+// directories as needed.
+func writeSRT(t *testing.T, path string, cues []srt.Cue) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := srt.Write(f, cues); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMergeSubtitlesKeepsCloseCuesWithinAChunk(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	// A single chunk's own SRT with two back-to-back cues under
+	// overlapTolerance apart, as whisper commonly emits during
+	// continuous speech. Neither should be dropped: the overlap check
+	// only applies across a chunk boundary.
+	writeSRT(t, "subtitles/part000.wav.srt", []srt.Cue{
+		{Index: 1, Start: 0, End: 2 * time.Second, Text: "Hello there"},
+		{Index: 2, Start: 2*time.Second + 100*time.Millisecond, End: 4 * time.Second, Text: "friend how are you"},
+	})
+
+	chunks := []Chunk{
+		{Index: 0, Start: 0, Input: "audio_parts/part000.wav", Output: "subtitles/part000.wav.srt"},
+	}
+
+	path, err := mergeSubtitles(chunks)
+	if err != nil {
+		t.Fatalf("mergeSubtitles: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := srt.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d cues, want 2 (both cues within a chunk must survive): %+v", len(got), got)
+	}
+}
+
+func TestMergeSubtitlesDropsDuplicateAtChunkBoundary(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	writeSRT(t, "subtitles/part000.wav.srt", []srt.Cue{
+		{Index: 1, Start: 28 * time.Second, End: 30 * time.Second, Text: "and then"},
+	})
+	writeSRT(t, "subtitles/part001.wav.srt", []srt.Cue{
+		// Re-transcribed tail of the previous chunk, close to its end
+		// once shifted by the second chunk's start offset.
+		{Index: 1, Start: 0, End: 500 * time.Millisecond, Text: "and then"},
+		{Index: 2, Start: 2 * time.Second, End: 4 * time.Second, Text: "we left"},
+	})
+
+	chunks := []Chunk{
+		{Index: 0, Start: 0, Input: "audio_parts/part000.wav", Output: "subtitles/part000.wav.srt"},
+		{Index: 1, Start: 30 * time.Second, Input: "audio_parts/part001.wav", Output: "subtitles/part001.wav.srt"},
+	}
+
+	path, err := mergeSubtitles(chunks)
+	if err != nil {
+		t.Fatalf("mergeSubtitles: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := srt.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d cues, want 2 (duplicate boundary cue dropped, real ones kept): %+v", len(got), got)
+	}
+	if got[1].Text != "we left" {
+		t.Fatalf("second cue = %q, want %q", got[1].Text, "we left")
+	}
+}
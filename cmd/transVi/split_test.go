@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanCutPointsFallsBackToHardCutWithNoSilence(t *testing.T) {
+	cuts := planCutPoints(65, nil, 30, 5)
+	want := []float64{0, 30, 60, 65}
+	if len(cuts) != len(want) {
+		t.Fatalf("got %v, want %v", cuts, want)
+	}
+	for i := range want {
+		if cuts[i] != want[i] {
+			t.Fatalf("got %v, want %v", cuts, want)
+		}
+	}
+}
+
+func TestPlanCutPointsSnapsToSilenceBeforeMaxLen(t *testing.T) {
+	silences := []silenceInterval{{Start: 24, End: 26}} // midpoint 25
+	cuts := planCutPoints(40, silences, 30, 5)
+	want := []float64{0, 25, 40}
+	if len(cuts) != len(want) {
+		t.Fatalf("got %v, want %v", cuts, want)
+	}
+	for i := range want {
+		if cuts[i] != want[i] {
+			t.Fatalf("got %v, want %v", cuts, want)
+		}
+	}
+}
+
+func TestPlanCutPointsSkipsSilenceTooCloseToChunkStart(t *testing.T) {
+	// Silence midpoint at 3s is within minLen (5s) of the chunk start, so
+	// it must not be used even though it's the only silence before maxLen.
+	silences := []silenceInterval{{Start: 2, End: 4}}
+	cuts := planCutPoints(35, silences, 30, 5)
+	want := []float64{0, 30, 35}
+	if len(cuts) != len(want) {
+		t.Fatalf("got %v, want %v", cuts, want)
+	}
+	for i := range want {
+		if cuts[i] != want[i] {
+			t.Fatalf("got %v, want %v", cuts, want)
+		}
+	}
+}
+
+func TestPlanCutPointsShorterThanMaxLenIsOneChunk(t *testing.T) {
+	cuts := planCutPoints(12, nil, 30, 5)
+	want := []float64{0, 12}
+	if len(cuts) != len(want) {
+		t.Fatalf("got %v, want %v", cuts, want)
+	}
+	for i := range want {
+		if cuts[i] != want[i] {
+			t.Fatalf("got %v, want %v", cuts, want)
+		}
+	}
+}
+
+func TestPlanCutPointsPrefersSilenceClosestToLimit(t *testing.T) {
+	silences := []silenceInterval{
+		{Start: 9, End: 11},  // midpoint 10
+		{Start: 19, End: 21}, // midpoint 20, closer to the 30s limit
+	}
+	cuts := planCutPoints(40, silences, 30, 5)
+	want := []float64{0, 20, 40}
+	if len(cuts) != len(want) {
+		t.Fatalf("got %v, want %v", cuts, want)
+	}
+	for i := range want {
+		if cuts[i] != want[i] {
+			t.Fatalf("got %v, want %v", cuts, want)
+		}
+	}
+}
+
+func TestDetectSilencesParsesSilencedetectOutput(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg not installed")
+	}
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "tone.wav")
+	// Two seconds of tone, then two seconds of silence, then one more
+	// second of tone, so silencedetect reports exactly one interval.
+	cmd := exec.Command("ffmpeg",
+		"-f", "lavfi", "-i", "sine=frequency=440:duration=2",
+		"-f", "lavfi", "-i", "anullsrc=r=8000:cl=mono:d=2",
+		"-f", "lavfi", "-i", "sine=frequency=440:duration=1",
+		"-filter_complex", "[0][1][2]concat=n=3:v=0:a=1",
+		"-ar", "8000", "-ac", "1", input,
+	)
+	if err := cmd.Run(); err != nil {
+		t.Skipf("could not synthesize test audio: %v", err)
+	}
+
+	silences, err := detectSilences(input, "-30dB", 0.4)
+	if err != nil {
+		t.Fatalf("detectSilences: %v", err)
+	}
+	if len(silences) != 1 {
+		t.Fatalf("got %d silences, want 1: %+v", len(silences), silences)
+	}
+	if silences[0].Start < 1.5 || silences[0].Start > 2.5 {
+		t.Errorf("silence start = %v, want ~2s", silences[0].Start)
+	}
+	if silences[0].End < 3.5 || silences[0].End > 4.5 {
+		t.Errorf("silence end = %v, want ~4s", silences[0].End)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplySubtitlesSidecarWritesSRTNextToOutput(t *testing.T) {
+	dir := t.TempDir()
+	mergedSRT := filepath.Join(dir, "merged_sub_titles.srt")
+	if err := os.WriteFile(mergedSRT, []byte("1\n00:00:00,000 --> 00:00:01,000\nhi\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "output.mp4")
+
+	if err := applySubtitles("input.mp4", mergedSRT, output, "sidecar", subtitleStyle{}); err != nil {
+		t.Fatalf("applySubtitles: %v", err)
+	}
+
+	sidecarPath := filepath.Join(dir, "output.srt")
+	got, err := os.ReadFile(sidecarPath)
+	if err != nil {
+		t.Fatalf("reading sidecar: %v", err)
+	}
+	want, err := os.ReadFile(mergedSRT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("sidecar content = %q, want %q", got, want)
+	}
+}
+
+func TestApplySubtitlesSoftRejectsIncompatibleContainer(t *testing.T) {
+	dir := t.TempDir()
+	mergedSRT := filepath.Join(dir, "merged_sub_titles.srt")
+	if err := os.WriteFile(mergedSRT, []byte("1\n00:00:00,000 --> 00:00:01,000\nhi\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "output.webm")
+
+	err := applySubtitles("input.mp4", mergedSRT, output, "soft", subtitleStyle{})
+	if err == nil {
+		t.Fatal("expected an error for a .webm output in soft mode, got nil")
+	}
+}
+
+func TestApplySubtitlesUnknownMode(t *testing.T) {
+	err := applySubtitles("input.mp4", "merged.srt", "output.mp4", "bogus", subtitleStyle{})
+	if err == nil {
+		t.Fatal("expected an error for an unknown -subtitle-mode, got nil")
+	}
+}
+
+func TestApplySubtitlesBurnRejectsUnknownPosition(t *testing.T) {
+	err := applySubtitles("input.mp4", "merged.srt", "output.mp4", "burn", subtitleStyle{Position: "sideways"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown -subtitle-position, got nil")
+	}
+}
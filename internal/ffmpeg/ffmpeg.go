@@ -0,0 +1,70 @@
+// Package ffmpeg parses ffmpeg/ffprobe output that transVi needs in more
+// than one place: the CSV a "-segment_list" run writes, and ffprobe's
+// reported media duration.
+package ffmpeg
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSegmentList reads the CSV ffmpeg wrote via "-segment_list" and
+// returns each segment's start time keyed by its base filename. ffmpeg
+// snaps segment boundaries to keyframes, so these are the only trustworthy
+// source of per-chunk start offsets.
+func ParseSegmentList(path string) (map[string]time.Duration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	starts := make(map[string]time.Duration)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			continue
+		}
+		startSeconds, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("ffmpeg: parse segment list %q: %w", line, err)
+		}
+		starts[filepath.Base(fields[0])] = time.Duration(startSeconds * float64(time.Second))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return starts, nil
+}
+
+// ProbeDuration returns path's duration in seconds via ffprobe.
+func ProbeDuration(path string) (float64, error) {
+	cmd := exec.Command("ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("ffmpeg: ffprobe: %w", err)
+	}
+	duration, err := strconv.ParseFloat(strings.TrimSpace(out.String()), 64)
+	if err != nil {
+		return 0, fmt.Errorf("ffmpeg: parse ffprobe output %q: %w", out.String(), err)
+	}
+	return duration, nil
+}
@@ -0,0 +1,75 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSegmentList(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "segments.csv")
+	content := "/tmp/part000.wav,0.000000,4.960000\n" +
+		"/tmp/part001.wav,4.960000,9.870000\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	starts, err := ParseSegmentList(path)
+	if err != nil {
+		t.Fatalf("ParseSegmentList: %v", err)
+	}
+	if len(starts) != 2 {
+		t.Fatalf("got %d entries, want 2", len(starts))
+	}
+	if starts["part000.wav"] != 0 {
+		t.Errorf("part000.wav start = %v, want 0", starts["part000.wav"])
+	}
+	want := time.Duration(4.96 * float64(time.Second))
+	if starts["part001.wav"] != want {
+		t.Errorf("part001.wav start = %v, want %v", starts["part001.wav"], want)
+	}
+}
+
+func TestParseSegmentListSkipsBlankAndShortLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "segments.csv")
+	content := "\n" +
+		"/tmp/part000.wav\n" +
+		"/tmp/part001.wav,1.500000,3.000000\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	starts, err := ParseSegmentList(path)
+	if err != nil {
+		t.Fatalf("ParseSegmentList: %v", err)
+	}
+	if len(starts) != 1 {
+		t.Fatalf("got %d entries, want 1", len(starts))
+	}
+	want := time.Duration(1.5 * float64(time.Second))
+	if starts["part001.wav"] != want {
+		t.Errorf("part001.wav start = %v, want %v", starts["part001.wav"], want)
+	}
+}
+
+func TestParseSegmentListRejectsMalformedTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "segments.csv")
+	content := "/tmp/part000.wav,not-a-number,3.000000\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseSegmentList(path); err == nil {
+		t.Fatal("expected an error for a malformed timestamp, got nil")
+	}
+}
+
+func TestParseSegmentListMissingFile(t *testing.T) {
+	if _, err := ParseSegmentList(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Fatal("expected an error for a missing file, got nil")
+	}
+}
@@ -0,0 +1,136 @@
+// Package manifest persists enough of a transVi run's state to resume it
+// after a crash instead of re-transcribing everything from scratch.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Chunk status values.
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+// ChunkState is the persisted record of one chunk's place in the segment
+// plan and how far it got through transcription.
+type ChunkState struct {
+	Index        int     `json:"index"`
+	StartSeconds float64 `json:"start_seconds"`
+	Status       string  `json:"status"`
+	Model        string  `json:"model"`
+	SRTPath      string  `json:"srt_path"`
+	DurationSecs float64 `json:"duration_seconds"`
+}
+
+// Manifest records a transVi run's segment plan and each chunk's
+// transcription status, keyed to the sha256 of the input file so a stale
+// manifest for a different (or since-edited) input is never reused.
+type Manifest struct {
+	mu sync.Mutex
+
+	InputHash string       `json:"input_hash"`
+	Chunks    []ChunkState `json:"chunks"`
+}
+
+// New builds a manifest for inputHash from an already-planned set of
+// chunk states (normally all StatusPending).
+func New(inputHash string, chunks []ChunkState) *Manifest {
+	return &Manifest{InputHash: inputHash, Chunks: chunks}
+}
+
+// HashFile returns the hex-encoded sha256 of the file at path.
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Load reads a manifest from path. It returns (nil, nil) if no manifest
+// exists there yet, so "no manifest" can be treated as a normal case
+// rather than an error.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("manifest: parse %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Save atomically writes m to path: it writes to a process-unique temp
+// file in the same directory and renames it over path, so a crash
+// mid-write never leaves a truncated manifest behind. The whole
+// marshal-write-rename sequence runs under m's mutex, so concurrent
+// callers (one per worker goroutine) can't race on a shared temp path or
+// interleave writes.
+func (m *Manifest) Save(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.tmp.%d", path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// SetStatus updates the status (and, once StatusDone, the recorded
+// duration) of the chunk at index. It is safe to call from multiple
+// worker goroutines concurrently.
+func (m *Manifest) SetStatus(index int, status string, durationSecs float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.Chunks {
+		if m.Chunks[i].Index == index {
+			m.Chunks[i].Status = status
+			if status == StatusDone {
+				m.Chunks[i].DurationSecs = durationSecs
+			}
+			return
+		}
+	}
+}
+
+// ChunkState returns the recorded state for index, if any.
+func (m *Manifest) ChunkState(index int) (ChunkState, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.Chunks {
+		if c.Index == index {
+			return c, true
+		}
+	}
+	return ChunkState{}, false
+}
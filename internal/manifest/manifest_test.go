@@ -0,0 +1,134 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestHashFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.mp4")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := HashFile(path)
+	if err != nil {
+		t.Fatalf("HashFile: %v", err)
+	}
+	// sha256("hello")
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if hash != want {
+		t.Fatalf("got %s, want %s", hash, want)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".transvi", "manifest.json")
+
+	m := New("deadbeef", []ChunkState{
+		{Index: 0, StartSeconds: 0, Status: StatusPending, Model: "whispercpp:base.en", SRTPath: "subtitles/part000.wav.srt"},
+	})
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded == nil {
+		t.Fatal("Load returned nil for an existing manifest")
+	}
+	if loaded.InputHash != "deadbeef" {
+		t.Fatalf("InputHash = %q, want %q", loaded.InputHash, "deadbeef")
+	}
+	if len(loaded.Chunks) != 1 || loaded.Chunks[0].Status != StatusPending {
+		t.Fatalf("Chunks = %+v", loaded.Chunks)
+	}
+
+	// No temp file should be left behind.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != "manifest.json" {
+			t.Errorf("unexpected leftover file %s", e.Name())
+		}
+	}
+}
+
+func TestLoadMissingReturnsNil(t *testing.T) {
+	dir := t.TempDir()
+	m, err := Load(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if m != nil {
+		t.Fatalf("got %+v, want nil for a missing manifest", m)
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	m := New("hash", []ChunkState{
+		{Index: 0, Status: StatusPending},
+		{Index: 1, Status: StatusPending},
+	})
+	m.SetStatus(1, StatusDone, 12.5)
+
+	cs, ok := m.ChunkState(1)
+	if !ok || cs.Status != StatusDone || cs.DurationSecs != 12.5 {
+		t.Fatalf("ChunkState(1) = %+v, ok=%v", cs, ok)
+	}
+	cs0, _ := m.ChunkState(0)
+	if cs0.Status != StatusPending {
+		t.Fatalf("unrelated chunk 0 changed: %+v", cs0)
+	}
+}
+
+// TestConcurrentSave reproduces the manifest corruption a reviewer found:
+// every worker goroutine calls Save on the same *Manifest right after its
+// chunk finishes, so Save must serialize the full marshal-write-rename
+// sequence rather than just the marshal step.
+func TestConcurrentSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.json")
+
+	var chunks []ChunkState
+	for i := 0; i < 20; i++ {
+		chunks = append(chunks, ChunkState{Index: i, Status: StatusPending})
+	}
+	m := New("hash", chunks)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(chunks))
+	for i := range chunks {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			m.SetStatus(index, StatusDone, 1)
+			if err := m.Save(path); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load after concurrent Save: %v", err)
+	}
+	for _, cs := range loaded.Chunks {
+		if cs.Status != StatusDone {
+			t.Errorf("chunk %d status = %q, want %q", cs.Index, cs.Status, StatusDone)
+		}
+	}
+}
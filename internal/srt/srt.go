@@ -0,0 +1,154 @@
+// Package srt implements a minimal parser and writer for the SubRip (.srt)
+// subtitle format, just enough to let transVi merge per-chunk subtitle
+// files into a single, correctly timed track.
+package srt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cue is a single subtitle entry: an index, a time range, and the text
+// shown during that range (one or more lines).
+type Cue struct {
+	Index int
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+const timestampFormat = "15:04:05,000"
+
+// Parse reads a .srt file from r and returns its cues in file order.
+// Malformed blocks (missing timestamp line, unparsable timestamps) are
+// skipped rather than aborting the whole parse, since whisper output
+// occasionally emits a trailing empty block.
+func Parse(r io.Reader) ([]Cue, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var cues []Cue
+	for scanner.Scan() {
+		indexLine := strings.TrimSpace(scanner.Text())
+		if indexLine == "" {
+			continue
+		}
+		index, err := strconv.Atoi(indexLine)
+		if err != nil {
+			// Not a cue index line; ignore and keep scanning.
+			continue
+		}
+
+		if !scanner.Scan() {
+			break
+		}
+		start, end, err := parseTimestampLine(scanner.Text())
+		if err != nil {
+			continue
+		}
+
+		var textLines []string
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.TrimSpace(line) == "" {
+				break
+			}
+			textLines = append(textLines, line)
+		}
+
+		cues = append(cues, Cue{
+			Index: index,
+			Start: start,
+			End:   end,
+			Text:  strings.Join(textLines, "\n"),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("srt: scan: %w", err)
+	}
+	return cues, nil
+}
+
+func parseTimestampLine(line string) (start, end time.Duration, err error) {
+	parts := strings.SplitN(line, "-->", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("srt: malformed timestamp line %q", line)
+	}
+	start, err = parseTimestamp(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err = parseTimestamp(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseTimestamp(s string) (time.Duration, error) {
+	// s is "HH:MM:SS,mmm"
+	s = strings.Replace(s, ",", ".", 1)
+	fields := strings.SplitN(s, ":", 3)
+	if len(fields) != 3 {
+		return 0, fmt.Errorf("srt: malformed timestamp %q", s)
+	}
+	hours, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, fmt.Errorf("srt: malformed timestamp %q: %w", s, err)
+	}
+	minutes, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, fmt.Errorf("srt: malformed timestamp %q: %w", s, err)
+	}
+	seconds, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("srt: malformed timestamp %q: %w", s, err)
+	}
+	total := time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second))
+	return total, nil
+}
+
+func formatTimestamp(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	hours := ms / 3600000
+	ms %= 3600000
+	minutes := ms / 60000
+	ms %= 60000
+	seconds := ms / 1000
+	ms %= 1000
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", hours, minutes, seconds, ms)
+}
+
+// Write serializes cues to w in standard .srt format, using each cue's
+// Index field as written (callers that need monotonic numbering should
+// renumber before calling Write).
+func Write(w io.Writer, cues []Cue) error {
+	for _, c := range cues {
+		_, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+			c.Index, formatTimestamp(c.Start), formatTimestamp(c.End), c.Text)
+		if err != nil {
+			return fmt.Errorf("srt: write: %w", err)
+		}
+	}
+	return nil
+}
+
+// Shift returns a copy of cues with Start and End offset by d.
+func Shift(cues []Cue, d time.Duration) []Cue {
+	shifted := make([]Cue, len(cues))
+	for i, c := range cues {
+		c.Start += d
+		c.End += d
+		shifted[i] = c
+	}
+	return shifted
+}
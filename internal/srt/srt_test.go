@@ -0,0 +1,83 @@
+package srt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,500\nHello there\n\n" +
+		"2\n00:00:02,600 --> 00:00:04,000\nfriend, how are you\n\n"
+
+	cues, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(cues) != 2 {
+		t.Fatalf("got %d cues, want 2", len(cues))
+	}
+
+	want := []Cue{
+		{Index: 1, Start: time.Second, End: 2500 * time.Millisecond, Text: "Hello there"},
+		{Index: 2, Start: 2600 * time.Millisecond, End: 4 * time.Second, Text: "friend, how are you"},
+	}
+	for i, c := range cues {
+		if c != want[i] {
+			t.Errorf("cue %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+func TestParseSkipsMalformedBlocks(t *testing.T) {
+	input := "not-a-number\nbogus line\n\n" +
+		"1\n00:00:01,000 --> 00:00:02,000\nreal cue\n\n"
+
+	cues, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(cues) != 1 || cues[0].Text != "real cue" {
+		t.Fatalf("got %+v, want a single real cue", cues)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	cues := []Cue{
+		{Index: 1, Start: 90 * time.Millisecond, End: time.Second, Text: "line one"},
+		{Index: 2, Start: 2 * time.Second, End: 3*time.Second + 250*time.Millisecond, Text: "line two\nwrapped"},
+	}
+
+	var buf strings.Builder
+	if err := Write(&buf, cues); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := Parse(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Parse after Write: %v", err)
+	}
+	if len(got) != len(cues) {
+		t.Fatalf("got %d cues, want %d", len(got), len(cues))
+	}
+	for i := range cues {
+		if got[i] != cues[i] {
+			t.Errorf("cue %d = %+v, want %+v", i, got[i], cues[i])
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	cues := []Cue{
+		{Index: 1, Start: time.Second, End: 2 * time.Second, Text: "a"},
+	}
+	shifted := Shift(cues, 30*time.Second)
+
+	if shifted[0].Start != 31*time.Second || shifted[0].End != 32*time.Second {
+		t.Fatalf("got start=%v end=%v, want start=31s end=32s", shifted[0].Start, shifted[0].End)
+	}
+	// Shift must not mutate the input slice.
+	if cues[0].Start != time.Second {
+		t.Fatalf("Shift mutated its input: %+v", cues[0])
+	}
+}
@@ -0,0 +1,219 @@
+package transcriber
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"transVi/internal/ffmpeg"
+	"transVi/internal/srt"
+)
+
+// defaultOpenAIMaxBytes is OpenAI's documented upload limit for the
+// audio transcriptions endpoint.
+const defaultOpenAIMaxBytes = 24 * 1024 * 1024
+
+// OpenAIAPI transcribes by calling OpenAI's /v1/audio/transcriptions
+// endpoint. Chunks above MaxBytes (or defaultOpenAIMaxBytes if unset) are
+// automatically re-split with ffmpeg before upload and the resulting SRTs
+// are stitched back together.
+type OpenAIAPI struct {
+	// APIKey is read from the OPENAI_API_KEY environment variable if empty.
+	APIKey string
+	// Model is the model name sent to the API, e.g. "whisper-1".
+	Model string
+	// Endpoint defaults to OpenAI's production URL if empty.
+	Endpoint string
+	// MaxBytes defaults to defaultOpenAIMaxBytes if zero.
+	MaxBytes int64
+	// RateLimiter throttles requests, if set.
+	RateLimiter *RateLimiter
+	// Client is used to send requests; http.DefaultClient if nil.
+	Client *http.Client
+}
+
+func (o OpenAIAPI) apiKey() string {
+	if o.APIKey != "" {
+		return o.APIKey
+	}
+	return os.Getenv("OPENAI_API_KEY")
+}
+
+func (o OpenAIAPI) endpoint() string {
+	if o.Endpoint != "" {
+		return o.Endpoint
+	}
+	return "https://api.openai.com/v1/audio/transcriptions"
+}
+
+func (o OpenAIAPI) maxBytes() int64 {
+	if o.MaxBytes > 0 {
+		return o.MaxBytes
+	}
+	return defaultOpenAIMaxBytes
+}
+
+func (o OpenAIAPI) client() *http.Client {
+	if o.Client != nil {
+		return o.Client
+	}
+	return http.DefaultClient
+}
+
+// Transcribe uploads audioPath (re-splitting first if it's over the size
+// cap) and returns the resulting SRT, offset and stitched back into a
+// single track if it had to be re-split.
+func (o OpenAIAPI) Transcribe(ctx context.Context, audioPath string) (io.ReadCloser, error) {
+	apiKey := o.apiKey()
+	if apiKey == "" {
+		return nil, fmt.Errorf("openai: OPENAI_API_KEY is not set")
+	}
+
+	parts, cleanup, err := o.splitIfOversized(audioPath)
+	if err != nil {
+		return nil, fmt.Errorf("openai: %w", err)
+	}
+	defer cleanup()
+
+	if len(parts) == 1 {
+		data, err := o.transcribeOne(ctx, apiKey, parts[0].Path)
+		if err != nil {
+			return nil, fmt.Errorf("openai: %w", err)
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	var merged []srt.Cue
+	for _, p := range parts {
+		data, err := o.transcribeOne(ctx, apiKey, p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("openai: part %s: %w", p.Path, err)
+		}
+		cues, err := srt.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("openai: parsing srt for part %s: %w", p.Path, err)
+		}
+		merged = append(merged, srt.Shift(cues, p.Start)...)
+	}
+	for i := range merged {
+		merged[i].Index = i + 1
+	}
+
+	var buf bytes.Buffer
+	if err := srt.Write(&buf, merged); err != nil {
+		return nil, fmt.Errorf("openai: %w", err)
+	}
+	return io.NopCloser(&buf), nil
+}
+
+// transcribeOne uploads a single file that is already under the size cap
+// and returns the raw SRT response body.
+func (o OpenAIAPI) transcribeOne(ctx context.Context, apiKey, path string) ([]byte, error) {
+	if err := o.RateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := multipartAudioBody("file", path, map[string]string{
+		"model":           o.Model,
+		"response_format": "srt",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := o.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", o.endpoint(), resp.Status, data)
+	}
+	return data, nil
+}
+
+// audioPart is one piece of a chunk that had to be re-split to fit under
+// the API's size cap, with its start offset within the original chunk.
+type audioPart struct {
+	Path  string
+	Start time.Duration
+}
+
+// splitIfOversized returns audioPath unchanged (as a single part) if it's
+// already under the size cap; otherwise it re-splits it into evenly sized
+// pieces with ffmpeg and returns those. The returned cleanup func removes
+// any temp files created.
+func (o OpenAIAPI) splitIfOversized(audioPath string) ([]audioPart, func(), error) {
+	info, err := os.Stat(audioPath)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	if info.Size() <= o.maxBytes() {
+		return []audioPart{{Path: audioPath}}, func() {}, nil
+	}
+
+	duration, err := ffmpeg.ProbeDuration(audioPath)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	numParts := int(math.Ceil(float64(info.Size()) / float64(o.maxBytes())))
+	if numParts < 2 {
+		numParts = 2
+	}
+	segLen := duration / float64(numParts)
+
+	tmpDir, err := os.MkdirTemp("", "transvi-openai-split-*")
+	if err != nil {
+		return nil, func() {}, err
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	segmentList := filepath.Join(tmpDir, "segments.csv")
+	cmd := exec.Command("ffmpeg",
+		"-i", audioPath,
+		"-f", "segment",
+		"-segment_time", fmt.Sprintf("%f", segLen),
+		"-reset_timestamps", "1",
+		"-segment_list", segmentList,
+		"-segment_list_type", "csv",
+		filepath.Join(tmpDir, "part%03d.wav"),
+	)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return nil, func() {}, fmt.Errorf("re-splitting oversized chunk: %w", err)
+	}
+
+	starts, err := ffmpeg.ParseSegmentList(segmentList)
+	if err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+
+	var parts []audioPart
+	for name, start := range starts {
+		parts = append(parts, audioPart{Path: filepath.Join(tmpDir, name), Start: start})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Start < parts[j].Start })
+	return parts, cleanup, nil
+}
@@ -0,0 +1,130 @@
+package transcriber
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIAPITranscribeSendsAuthAndModel(t *testing.T) {
+	audioPath := writeTempAudio(t)
+
+	var gotAuth, gotModel, gotFormat string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		gotModel = r.FormValue("model")
+		gotFormat = r.FormValue("response_format")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, "1\n00:00:00,000 --> 00:00:01,000\nhi\n\n")
+	}))
+	defer srv.Close()
+
+	o := OpenAIAPI{APIKey: "sk-test", Model: "whisper-1", Endpoint: srv.URL}
+	rc, err := o.Transcribe(context.Background(), audioPath)
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	defer rc.Close()
+
+	if gotAuth != "Bearer sk-test" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer sk-test")
+	}
+	if gotModel != "whisper-1" {
+		t.Errorf("model = %q, want %q", gotModel, "whisper-1")
+	}
+	if gotFormat != "srt" {
+		t.Errorf("response_format = %q, want %q", gotFormat, "srt")
+	}
+}
+
+func TestOpenAIAPITranscribeMissingAPIKey(t *testing.T) {
+	audioPath := writeTempAudio(t)
+	t.Setenv("OPENAI_API_KEY", "")
+
+	o := OpenAIAPI{}
+	if _, err := o.Transcribe(context.Background(), audioPath); err == nil {
+		t.Fatal("expected an error when no API key is set, got nil")
+	}
+}
+
+func TestOpenAIAPITranscribeNonOKStatus(t *testing.T) {
+	audioPath := writeTempAudio(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, "rate limited")
+	}))
+	defer srv.Close()
+
+	o := OpenAIAPI{APIKey: "sk-test", Endpoint: srv.URL}
+	_, err := o.Transcribe(context.Background(), audioPath)
+	if err == nil {
+		t.Fatal("expected an error for a non-200 response, got nil")
+	}
+	if !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("error = %v, want it to include the response body", err)
+	}
+}
+
+func TestSplitIfOversizedLeavesSmallFileAlone(t *testing.T) {
+	audioPath := writeTempAudio(t)
+
+	o := OpenAIAPI{MaxBytes: 1 << 20}
+	parts, cleanup, err := o.splitIfOversized(audioPath)
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("splitIfOversized: %v", err)
+	}
+	if len(parts) != 1 || parts[0].Path != audioPath {
+		t.Fatalf("parts = %+v, want a single unchanged part", parts)
+	}
+}
+
+func TestSplitIfOversizedResplitsAboveCap(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg not installed")
+	}
+	if _, err := exec.LookPath("ffprobe"); err != nil {
+		t.Skip("ffprobe not installed")
+	}
+
+	dir := t.TempDir()
+	audioPath := filepath.Join(dir, "chunk.wav")
+	cmd := exec.Command("ffmpeg", "-f", "lavfi", "-i", "sine=frequency=440:duration=2",
+		"-ar", "8000", "-ac", "1", audioPath)
+	if err := cmd.Run(); err != nil {
+		t.Skipf("could not synthesize test audio: %v", err)
+	}
+
+	info, err := os.Stat(audioPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := OpenAIAPI{MaxBytes: info.Size() / 2}
+	parts, cleanup, err := o.splitIfOversized(audioPath)
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("splitIfOversized: %v", err)
+	}
+	if len(parts) < 2 {
+		t.Fatalf("got %d parts, want at least 2 for a file over the size cap", len(parts))
+	}
+	for i, p := range parts {
+		if _, err := os.Stat(p.Path); err != nil {
+			t.Errorf("part %d: %v", i, err)
+		}
+		if i > 0 && parts[i-1].Start >= p.Start {
+			t.Errorf("parts not sorted by start: %+v", parts)
+		}
+	}
+}
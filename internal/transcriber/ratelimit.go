@@ -0,0 +1,63 @@
+package transcriber
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a simple token bucket used by the network-backed
+// transcribers to stay under a backend's requests-per-second budget
+// instead of hammering it into 429s.
+type RateLimiter struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	burst      float64
+	tokens     float64
+	last       time.Time
+}
+
+// NewRateLimiter returns a limiter that allows ratePerSec requests per
+// second on average, with bursts up to burst requests. A ratePerSec of
+// zero or less disables limiting: Wait always returns immediately.
+func NewRateLimiter(ratePerSec float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		ratePerSec: ratePerSec,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		last:       time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	if r == nil || r.ratePerSec <= 0 {
+		return nil
+	}
+
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		r.tokens += now.Sub(r.last).Seconds() * r.ratePerSec
+		if r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+		r.last = now
+
+		if r.tokens >= 1 {
+			r.tokens--
+			r.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - r.tokens) / r.ratePerSec * float64(time.Second))
+		r.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package transcriber
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterDisabledWhenRateIsZero(t *testing.T) {
+	var r *RateLimiter
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("nil limiter: Wait returned %v, want nil", err)
+	}
+
+	zero := NewRateLimiter(0, 1)
+	if err := zero.Wait(context.Background()); err != nil {
+		t.Fatalf("zero-rate limiter: Wait returned %v, want nil", err)
+	}
+}
+
+func TestRateLimiterAllowsBurstWithoutBlocking(t *testing.T) {
+	r := NewRateLimiter(1, 5)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := r.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("burst of 5 took %v, want near-instant", elapsed)
+	}
+}
+
+func TestRateLimiterBlocksThenUnblocks(t *testing.T) {
+	r := NewRateLimiter(20, 1) // one token every 50ms
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+
+	start := time.Now()
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("second Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("second Wait returned after %v, want it to block for ~50ms", elapsed)
+	}
+}
+
+func TestRateLimiterRespectsContextCancellation(t *testing.T) {
+	r := NewRateLimiter(0.1, 1) // next token ~10s away
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := r.Wait(ctx)
+	if err != ctx.Err() {
+		t.Fatalf("Wait returned %v, want %v", err, ctx.Err())
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("Wait took %v to respect cancellation, want well under the token interval", elapsed)
+	}
+}
@@ -0,0 +1,17 @@
+// Package transcriber abstracts over the different ways transVi can turn
+// an audio chunk into an SRT file, so the worker pool in main doesn't need
+// to know whether it's shelling out to a local binary, calling an HTTP
+// server, or hitting a cloud API.
+package transcriber
+
+import (
+	"context"
+	"io"
+)
+
+// Transcriber turns the audio file at audioPath into subtitles and
+// returns them as an already-parsed-ready SRT stream. Callers must Close
+// the returned ReadCloser.
+type Transcriber interface {
+	Transcribe(ctx context.Context, audioPath string) (io.ReadCloser, error)
+}
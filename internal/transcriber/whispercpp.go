@@ -0,0 +1,65 @@
+package transcriber
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// WhisperCPP transcribes by shelling out to a whisper.cpp "main" binary.
+type WhisperCPP struct {
+	// BinaryPath is the path to whisper.cpp's "main" executable.
+	BinaryPath string
+	// ModelPath is the .bin model file to load, e.g. "models/ggml-base.en.bin".
+	ModelPath string
+	// Threads is passed as -t; zero leaves it at whisper.cpp's default.
+	Threads int
+	// Language is passed as -l, e.g. "en" or "auto".
+	Language string
+	// Translate asks whisper.cpp to translate the audio to English.
+	Translate bool
+}
+
+// Transcribe runs whisper.cpp against audioPath and returns its SRT
+// output. whisper.cpp writes to "<prefix>.srt" rather than stdout, so this
+// runs it against a temp file prefix and reads that file back.
+func (w WhisperCPP) Transcribe(ctx context.Context, audioPath string) (io.ReadCloser, error) {
+	tmp, err := os.CreateTemp("", "transvi-whispercpp-*")
+	if err != nil {
+		return nil, fmt.Errorf("whispercpp: %w", err)
+	}
+	prefix := tmp.Name()
+	tmp.Close()
+	os.Remove(prefix)
+	defer os.Remove(prefix + ".srt")
+
+	args := []string{
+		"-m", w.ModelPath,
+		"-f", audioPath,
+		"-l", w.Language,
+		"-osrt",
+		"-of", prefix,
+	}
+	if w.Threads > 0 {
+		args = append(args, "-t", strconv.Itoa(w.Threads))
+	}
+	if w.Translate {
+		args = append(args, "-tr")
+	}
+
+	cmd := exec.CommandContext(ctx, w.BinaryPath, args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("whispercpp: %s: %w", w.BinaryPath, err)
+	}
+
+	data, err := os.ReadFile(prefix + ".srt")
+	if err != nil {
+		return nil, fmt.Errorf("whispercpp: reading output: %w", err)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
@@ -0,0 +1,97 @@
+package transcriber
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// WhisperHTTP transcribes by POSTing the audio file as multipart form data
+// to an HTTP server compatible with faster-whisper-server or whisper.cpp's
+// own server mode, and reading back the SRT it returns.
+type WhisperHTTP struct {
+	// Endpoint is the full URL to POST to, e.g. "http://localhost:8080/inference".
+	Endpoint string
+	// FieldName is the multipart field the server expects the audio
+	// under. Defaults to "file" if empty.
+	FieldName string
+	// Client is used to send the request; http.DefaultClient if nil.
+	Client *http.Client
+	// RateLimiter throttles requests, if set.
+	RateLimiter *RateLimiter
+}
+
+// Transcribe uploads audioPath to w.Endpoint and returns the response body.
+func (w WhisperHTTP) Transcribe(ctx context.Context, audioPath string) (io.ReadCloser, error) {
+	if err := w.RateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("whisperhttp: %w", err)
+	}
+
+	body, contentType, err := multipartAudioBody(w.fieldName(), audioPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("whisperhttp: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("whisperhttp: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("whisperhttp: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("whisperhttp: %s returned %s: %s", w.Endpoint, resp.Status, msg)
+	}
+	return resp.Body, nil
+}
+
+func (w WhisperHTTP) fieldName() string {
+	if w.FieldName != "" {
+		return w.FieldName
+	}
+	return "file"
+}
+
+// multipartAudioBody builds a multipart/form-data body containing the file
+// at audioPath under fieldName, plus any extra string fields.
+func multipartAudioBody(fieldName, audioPath string, extraFields map[string]string) (io.Reader, string, error) {
+	f, err := os.Open(audioPath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	part, err := writer.CreateFormFile(fieldName, filepath.Base(audioPath))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, "", err
+	}
+	for k, v := range extraFields {
+		if err := writer.WriteField(k, v); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, writer.FormDataContentType(), nil
+}
@@ -0,0 +1,110 @@
+package transcriber
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTempAudio(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chunk.wav")
+	if err := os.WriteFile(path, []byte("fake wav data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWhisperHTTPTranscribeSendsMultipartAndReturnsBody(t *testing.T) {
+	audioPath := writeTempAudio(t)
+
+	var gotField string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		files := r.MultipartForm.File
+		for field, headers := range files {
+			gotField = field
+			if len(headers) != 1 || headers[0].Filename != "chunk.wav" {
+				t.Errorf("unexpected file headers: %+v", headers)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, "1\n00:00:00,000 --> 00:00:01,000\nhi\n\n")
+	}))
+	defer srv.Close()
+
+	wh := WhisperHTTP{Endpoint: srv.URL}
+	rc, err := wh.Transcribe(context.Background(), audioPath)
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	defer rc.Close()
+
+	if gotField != "file" {
+		t.Errorf("multipart field = %q, want %q", gotField, "file")
+	}
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !strings.Contains(string(data), "hi") {
+		t.Errorf("body = %q, want it to contain the SRT text", data)
+	}
+}
+
+func TestWhisperHTTPTranscribeUsesCustomFieldName(t *testing.T) {
+	audioPath := writeTempAudio(t)
+
+	var gotField string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		for field := range r.MultipartForm.File {
+			gotField = field
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	wh := WhisperHTTP{Endpoint: srv.URL, FieldName: "audio"}
+	rc, err := wh.Transcribe(context.Background(), audioPath)
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	rc.Close()
+
+	if gotField != "audio" {
+		t.Errorf("multipart field = %q, want %q", gotField, "audio")
+	}
+}
+
+func TestWhisperHTTPTranscribeNonOKStatus(t *testing.T) {
+	audioPath := writeTempAudio(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, "model failed to load")
+	}))
+	defer srv.Close()
+
+	wh := WhisperHTTP{Endpoint: srv.URL}
+	_, err := wh.Transcribe(context.Background(), audioPath)
+	if err == nil {
+		t.Fatal("expected an error for a non-200 response, got nil")
+	}
+	if !strings.Contains(err.Error(), "model failed to load") {
+		t.Errorf("error = %v, want it to include the response body", err)
+	}
+}